@@ -0,0 +1,232 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"jalan.io/internal/nuc"
+	"jalan.io/internal/profileutil"
+)
+
+// toChar is the inverse of nuc.Pack, whose (b>>1)&3 maps A->0, C->1,
+// T->2, G->3.
+var toChar = [4]byte{'A', 'C', 'T', 'G'}
+
+var fragments = []string{"GGT", "GGTA", "GGTATT", "GGTATTTTAATT", "GGTATTTTAATTTATAGT"}
+
+const hashSize = 1 << 18
+
+func hash(key uint64) int {
+	return int(key) % hashSize
+}
+
+type entry struct {
+	key   uint64
+	value int
+	next  *entry
+}
+
+type hashCounter struct {
+	entries [hashSize]*entry
+}
+
+func (hc *hashCounter) get(key uint64) int {
+	for e := hc.entries[hash(key)]; e != nil; e = e.next {
+		if e.key == key {
+			return e.value
+		}
+	}
+	return 0
+}
+
+func (hc *hashCounter) add(key uint64, n int) {
+	h := hash(key)
+	p := &hc.entries[h]
+	for e := *p; e != nil; e = e.next {
+		if e.key == key {
+			e.value += n
+			return
+		}
+	}
+	e := &entry{key, n, nil}
+	e.next = *p
+	*p = e
+}
+
+func (hc *hashCounter) inc(key uint64) {
+	hc.add(key, 1)
+}
+
+func (hc *hashCounter) merge(other *hashCounter) {
+	for _, head := range other.entries {
+		for e := head; e != nil; e = e.next {
+			hc.add(e.key, e.value)
+		}
+	}
+}
+
+func main() {
+	stop := profileutil.Start()
+	defer stop()
+
+	ctx := context.Background()
+
+	var dna []byte
+	profileutil.Do(ctx, "read", func(context.Context) {
+		dna = read()
+	})
+
+	results := make([]*hashCounter, 2+len(fragments))
+	profileutil.Do(ctx, "count", func(context.Context) {
+		var wg sync.WaitGroup
+
+		wg.Add(2)
+		go func() { defer wg.Done(); results[0] = countParallel(dna, 1) }()
+		go func() { defer wg.Done(); results[1] = countParallel(dna, 2) }()
+
+		for i, frag := range fragments {
+			i, frag := i, frag
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				results[2+i] = countParallel(dna, len(frag))
+			}()
+		}
+		wg.Wait()
+	})
+
+	profileutil.Do(ctx, "lookup", func(context.Context) {
+		fmt.Print(frequencies(results[0], 1))
+		fmt.Print(frequencies(results[1], 2))
+		for i, frag := range fragments {
+			packed := []byte(frag)
+			nuc.Pack(packed, packed)
+			fmt.Printf("%v\t%v\n", results[2+i].get(encode(packed)), frag)
+		}
+	})
+}
+
+// read skips the FASTA headers up to and including ">THREE" and returns
+// the 2-bit encoded payload that follows it.
+func read() []byte {
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		if strings.HasPrefix(scanner.Text(), ">THREE") {
+			break
+		}
+	}
+
+	var dna []byte
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) > 0 && line[0] == '>' {
+			break
+		}
+		packed := make([]byte, len(line))
+		nuc.Pack(packed, line)
+		dna = append(dna, packed...)
+	}
+	return dna
+}
+
+// countParallel counts every k-mer of the given length in dna, splitting
+// the work across runtime.NumCPU() workers that each own a byte range
+// and merging their local counts at the end.
+func countParallel(dna []byte, length int) *hashCounter {
+	n := len(dna) - length + 1
+	if n <= 0 {
+		return &hashCounter{}
+	}
+
+	workers := runtime.NumCPU()
+	chunk := (n + workers - 1) / workers
+
+	locals := make([]*hashCounter, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		start := w * chunk
+		if start >= n {
+			break
+		}
+		end := start + chunk
+		if end > n {
+			end = n
+		}
+
+		w, start, end := w, start, end
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			local := &hashCounter{}
+			for i := start; i < end; i++ {
+				local.inc(encode(dna[i : i+length]))
+			}
+			locals[w] = local
+		}()
+	}
+	wg.Wait()
+
+	merged := &hashCounter{}
+	for _, local := range locals {
+		if local != nil {
+			merged.merge(local)
+		}
+	}
+	return merged
+}
+
+func encode(sequence []byte) uint64 {
+	var num uint64
+	for _, char := range sequence {
+		num = (num << 2) | uint64(char)
+	}
+	return num
+}
+
+func decode(key uint64, length int) string {
+	letters := make([]byte, length)
+	for i := length - 1; i >= 0; i-- {
+		letters[i] = toChar[key&3]
+		key >>= 2
+	}
+	return string(letters)
+}
+
+// frequencies renders a sorted frequency table for every k-mer of the
+// given length, in the "LETTERS FREQ.PCT\n" format used by the
+// Benchmarks Game reference output.
+func frequencies(hc *hashCounter, length int) string {
+	type count struct {
+		key   uint64
+		value int
+	}
+
+	var counts []count
+	var total int
+	for _, head := range hc.entries {
+		for e := head; e != nil; e = e.next {
+			counts = append(counts, count{e.key, e.value})
+			total += e.value
+		}
+	}
+
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].value != counts[j].value {
+			return counts[i].value > counts[j].value
+		}
+		return decode(counts[i].key, length) < decode(counts[j].key, length)
+	})
+
+	var b strings.Builder
+	for _, c := range counts {
+		fmt.Fprintf(&b, "%s %.3f\n", decode(c.key, length), 100*float64(c.value)/float64(total))
+	}
+	b.WriteByte('\n')
+	return b.String()
+}
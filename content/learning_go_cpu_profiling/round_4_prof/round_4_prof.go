@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/bits"
+	"os"
+	"runtime"
+	"sync"
+
+	"jalan.io/internal/nuc"
+	"jalan.io/internal/profileutil"
+)
+
+const hashSize = 1 << 18
+
+func hash(key uint64) int {
+	return int(key) % hashSize
+}
+
+type entry struct {
+	key   uint64
+	value int
+	next  *entry
+}
+
+// shard is one bucket array of a ShardedCounter, guarded by its own
+// mutex so workers touching different shards never contend.
+type shard struct {
+	mu      sync.Mutex
+	entries [hashSize]*entry
+}
+
+func (s *shard) inc(k uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	h := hash(k)
+	p := &s.entries[h]
+	for e := *p; e != nil; e = e.next {
+		if e.key == k {
+			e.value++
+			return
+		}
+	}
+	e := &entry{k, 1, nil}
+	e.next = *p
+	*p = e
+}
+
+func (s *shard) get(k uint64) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for e := s.entries[hash(k)]; e != nil; e = e.next {
+		if e.key == k {
+			return e.value
+		}
+	}
+	return 0
+}
+
+// ShardedCounter is a hashCounter split into N shards (N a power of two)
+// so that concurrent k-mer counting rarely blocks on a single mutex.
+type ShardedCounter struct {
+	shards []*shard
+	mask   uint64
+}
+
+func newShardedCounter(n int) *ShardedCounter {
+	if n < 1 {
+		n = 1
+	}
+	n = 1 << uint(bits.Len(uint(n-1)))
+	shards := make([]*shard, n)
+	for i := range shards {
+		shards[i] = &shard{}
+	}
+	return &ShardedCounter{shards: shards, mask: uint64(n - 1)}
+}
+
+func (sc *ShardedCounter) inc(k uint64) {
+	sc.shards[k&sc.mask].inc(k)
+}
+
+func (sc *ShardedCounter) get(k uint64) int {
+	return sc.shards[k&sc.mask].get(k)
+}
+
+func main() {
+	stop := profileutil.Start()
+	defer stop()
+
+	ctx := context.Background()
+	sequence := []byte("GGTATTTTAATTTATAGT")
+	nuc.Pack(sequence, sequence)
+	key := encode(sequence)
+
+	workers := runtime.NumCPU()
+	counts := newShardedCounter(workers)
+	profileutil.Do(ctx, "read", func(context.Context) {
+		if err := nuc.Stream(os.Stdin, len(sequence), workers, counts.inc); err != nil {
+			fmt.Fprintln(os.Stderr, "stream:", err)
+			os.Exit(1)
+		}
+	})
+
+	var seqCount int
+	profileutil.Do(ctx, "lookup", func(context.Context) {
+		seqCount = counts.get(key)
+	})
+
+	fmt.Printf("%v\t%v\n", seqCount, "GGTATTTTAATTTATAGT")
+}
+
+func encode(sequence []byte) uint64 {
+	var num uint64
+	for _, char := range sequence {
+		num = (num << 2) | uint64(char)
+	}
+	return num
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"jalan.io/internal/nuc"
+)
+
+func TestShardedCounterIncGet(t *testing.T) {
+	sc := newShardedCounter(4)
+	sc.inc(42)
+	sc.inc(42)
+	sc.inc(7)
+
+	if got := sc.get(42); got != 2 {
+		t.Errorf("get(42) = %d, want 2", got)
+	}
+	if got := sc.get(7); got != 1 {
+		t.Errorf("get(7) = %d, want 1", got)
+	}
+	if got := sc.get(99); got != 0 {
+		t.Errorf("get(99) = %d, want 0", got)
+	}
+}
+
+func bruteForceCount(dna []byte, k int) map[uint64]int {
+	counts := make(map[uint64]int)
+	for i := 0; i+k <= len(dna); i++ {
+		counts[encode(dna[i:i+k])]++
+	}
+	return counts
+}
+
+func TestStreamMatchesBruteForce(t *testing.T) {
+	const k = 3
+	bases := []byte("ACGTACGTACGTGGTACGTGGTACGT")
+
+	packed := make([]byte, len(bases))
+	nuc.Pack(packed, bases)
+	want := bruteForceCount(packed, k)
+
+	input := []byte(">ONE description\nACGT\n>THREE Homo sapiens frequency\n" + string(bases) + "\n")
+
+	for _, workers := range []int{1, 2, 4} {
+		sc := newShardedCounter(workers)
+		if err := nuc.Stream(bytes.NewReader(input), k, workers, sc.inc); err != nil {
+			t.Fatalf("workers=%d: Stream: %v", workers, err)
+		}
+		for key, wantCount := range want {
+			if got := sc.get(key); got != wantCount {
+				t.Errorf("workers=%d: get(%d) = %d, want %d", workers, key, got, wantCount)
+			}
+		}
+	}
+}
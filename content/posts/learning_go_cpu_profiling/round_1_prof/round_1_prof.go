@@ -3,19 +3,30 @@ package main
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"fmt"
 	"os"
-	"runtime/pprof"
+
+	"jalan.io/internal/profileutil"
 )
 
 func main() {
-	f, _ := os.Create("round_1.prof")
-	pprof.StartCPUProfile(f)
-	defer pprof.StopCPUProfile()
+	stop := profileutil.Start()
+	defer stop()
 
+	ctx := context.Background()
 	sequence := "GGTATTTTAATTTATAGT"
-	dna := read()
-	counts := count(dna, len(sequence))
+
+	var dna []byte
+	profileutil.Do(ctx, "read", func(context.Context) {
+		dna = read()
+	})
+
+	var counts map[string]int
+	profileutil.Do(ctx, "count", func(context.Context) {
+		counts = count(dna, len(sequence))
+	})
+
 	fmt.Printf("%v\t%v\n", counts[sequence], sequence)
 }
 
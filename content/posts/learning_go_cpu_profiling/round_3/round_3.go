@@ -5,22 +5,19 @@ import (
 	"bytes"
 	"fmt"
 	"os"
-	"strings"
-)
 
-var toNum = strings.NewReplacer(
-	"A", string(0),
-	"C", string(1),
-	"G", string(2),
-	"T", string(3),
+	"jalan.io/internal/nuc"
 )
 
 func main() {
 	sequence := "GGTATTTTAATTTATAGT"
+	packed := []byte(sequence)
+	nuc.Pack(packed, packed)
+
 	dna := read()
-	counts := count(dna, len(sequence))
+	counts := count(dna, len(packed))
 	seqCount := 0
-	p, ok := counts[encode([]byte(toNum.Replace(sequence)))]
+	p, ok := counts[encode(packed)]
 	if ok {
 		seqCount = *p
 	}
@@ -31,7 +28,10 @@ func read() []byte {
 	var buf bytes.Buffer
 	scanner := bufio.NewScanner(os.Stdin)
 	for scanner.Scan() {
-		buf.WriteString(toNum.Replace(scanner.Text()))
+		line := scanner.Bytes()
+		packed := make([]byte, len(line))
+		nuc.Pack(packed, line)
+		buf.Write(packed)
 	}
 	return buf.Bytes()
 }
@@ -0,0 +1,167 @@
+// Package nuc provides shared helpers for packing nucleotide bytes into
+// their 2-bit codes, used across the k-nucleotide benchmark variants.
+package nuc
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"sync"
+)
+
+// Pack maps each ASCII nucleotide byte in src to its 2-bit code
+// (A->0, C->1, T->2, G->3) and writes the result to dst, which may
+// alias src. The mapping relies on the low bits of the ASCII codes for
+// A, C, G and T and produces meaningless output for any other byte.
+func Pack(dst, src []byte) {
+	for i, b := range src {
+		dst[i] = (b >> 1) & 3
+	}
+}
+
+var threeHeader = []byte(">THREE")
+
+// streamChunkSize bounds how many packed bases are buffered before being
+// handed to a worker, keeping Stream's peak memory at O(workers) chunks
+// rather than O(input size).
+const streamChunkSize = 1 << 16
+
+// a chunk is a run of packed bases together with the k-1 packed bases
+// immediately preceding it (its "prefix"), so a worker can reconstruct
+// the rolling window and count every k-mer in bases on its own, the
+// same way Count extends each of its ranges by k-1 bytes. Unlike a
+// precomputed rolling window, the prefix costs O(k) to produce per
+// chunk regardless of chunk size, so chunking never becomes a serial
+// bottleneck.
+type chunk struct {
+	prefix []byte
+	bases  []byte
+}
+
+// Stream reads FASTA-format data from r, skipping every header line (one
+// beginning with '>') until it finds a line starting with ">THREE", then
+// calls emit for every complete, 2-bit encoded k-mer in the payload that
+// follows. It never buffers the full payload: only a bounded pipeline of
+// fixed-size chunks is kept in flight, processed by worker goroutines so
+// emit (typically a ShardedCounter's inc) runs concurrently instead of
+// on a single thread.
+func Stream(r io.Reader, k int, workers int, emit func(kmer uint64)) error {
+	if workers < 1 {
+		workers = 1
+	}
+
+	chunks := make(chan chunk, workers*2)
+	var wg sync.WaitGroup
+	mask := uint64(1)<<uint(2*k) - 1
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for c := range chunks {
+				if len(c.prefix) < k-1 {
+					combined := append(append([]byte(nil), c.prefix...), c.bases...)
+					if len(combined) < k {
+						continue
+					}
+					num := encode(combined[:k-1])
+					for i := 0; i+k <= len(combined); i++ {
+						num = ((num << 2) | uint64(combined[i+k-1])) & mask
+						emit(num)
+					}
+					continue
+				}
+
+				num := encode(c.prefix)
+				for _, b := range c.bases {
+					num = ((num << 2) | uint64(b)) & mask
+					emit(num)
+				}
+			}
+		}()
+	}
+
+	br := bufio.NewReaderSize(r, 1<<20)
+	var capturing bool
+	var tail []byte // up to k-1 packed bases trailing the last chunk sent
+	buf := make([]byte, 0, streamChunkSize)
+
+	flush := func() {
+		if len(buf) == 0 {
+			return
+		}
+		bases := make([]byte, len(buf))
+		copy(bases, buf)
+		chunks <- chunk{prefix: tail, bases: bases}
+		tail = lastBytes(tail, bases, k-1)
+		buf = buf[:0]
+	}
+
+	runErr := func() error {
+		for {
+			line, err := br.ReadBytes('\n')
+			line = bytes.TrimRight(line, "\r\n")
+
+			if len(line) > 0 && line[0] == '>' {
+				flush()
+				capturing = bytes.HasPrefix(line, threeHeader)
+				if capturing {
+					tail = nil
+				}
+			} else if capturing {
+				for _, b := range line {
+					buf = append(buf, (b>>1)&3)
+					if len(buf) == streamChunkSize {
+						flush()
+					}
+				}
+			}
+
+			if err != nil {
+				flush()
+				if err == io.EOF {
+					return nil
+				}
+				return err
+			}
+		}
+	}()
+
+	close(chunks)
+	wg.Wait()
+	return runErr
+}
+
+// lastBytes returns, as a freshly allocated slice, the final n bytes of
+// the conceptual concatenation of prev and next (or fewer if that
+// concatenation is shorter than n).
+func lastBytes(prev, next []byte, n int) []byte {
+	if n <= 0 {
+		return nil
+	}
+	total := len(prev) + len(next)
+	if total <= n {
+		out := make([]byte, 0, total)
+		out = append(out, prev...)
+		out = append(out, next...)
+		return out
+	}
+
+	out := make([]byte, n)
+	if len(next) >= n {
+		copy(out, next[len(next)-n:])
+	} else {
+		fromPrev := n - len(next)
+		copy(out, prev[len(prev)-fromPrev:])
+		copy(out[fromPrev:], next)
+	}
+	return out
+}
+
+func encode(sequence []byte) uint64 {
+	var num uint64
+	for _, b := range sequence {
+		num = (num << 2) | uint64(b)
+	}
+	return num
+}
@@ -0,0 +1,93 @@
+package nuc
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+)
+
+func TestPackRoundTrip(t *testing.T) {
+	toChar := [4]byte{'A', 'C', 'T', 'G'}
+	bases := []byte("ACGT")
+
+	packed := make([]byte, len(bases))
+	Pack(packed, bases)
+
+	for i, want := range bases {
+		if got := toChar[packed[i]]; got != want {
+			t.Errorf("Pack(%q)[%d] = %d, decodes to %c, want %c", bases, i, packed[i], got, want)
+		}
+	}
+}
+
+// bruteCount counts every k-mer in dna by brute force, for comparison
+// against Stream's output.
+func bruteCount(dna []byte, k int) map[uint64]int {
+	counts := make(map[uint64]int)
+	for i := 0; i+k <= len(dna); i++ {
+		var key uint64
+		for _, b := range dna[i : i+k] {
+			key = (key << 2) | uint64(b)
+		}
+		counts[key]++
+	}
+	return counts
+}
+
+// syncMap is a trivial thread-safe map[uint64]int, standing in for a
+// real counter (e.g. a ShardedCounter) in tests.
+type syncMap struct {
+	mu     sync.Mutex
+	counts map[uint64]int
+}
+
+func (m *syncMap) inc(k uint64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.counts == nil {
+		m.counts = make(map[uint64]int)
+	}
+	m.counts[k]++
+}
+
+func TestStreamMatchesBruteForce(t *testing.T) {
+	const k = 4
+	bases := []byte("GGTATTTTAATTTATAGTACGTACGTGGTACGTTTAA")
+	packed := make([]byte, len(bases))
+	Pack(packed, bases)
+	want := bruteCount(packed, k)
+
+	for _, workers := range []int{1, 2, 4} {
+		// A realistic header carries a description after the id, and
+		// other records may appear before and after ">THREE".
+		input := []byte(">ONE some other record\nACGTACGT\n" +
+			">THREE Homo sapiens frequency\n" + string(bases) + "\n" +
+			">FOUR trailing record\nACGT\n")
+
+		got := &syncMap{}
+		if err := Stream(bytes.NewReader(input), k, workers, got.inc); err != nil {
+			t.Fatalf("workers=%d: Stream: %v", workers, err)
+		}
+
+		if len(got.counts) != len(want) {
+			t.Errorf("workers=%d: got %d distinct k-mers, want %d", workers, len(got.counts), len(want))
+		}
+		for key, wantCount := range want {
+			if got := got.counts[key]; got != wantCount {
+				t.Errorf("workers=%d: counts[%d] = %d, want %d", workers, key, got, wantCount)
+			}
+		}
+	}
+}
+
+func TestStreamRequiresThreePrefix(t *testing.T) {
+	input := []byte(">ONE\nACGT\n>TWOTHREE\nACGT\n")
+
+	got := &syncMap{}
+	if err := Stream(bytes.NewReader(input), 2, 1, got.inc); err != nil {
+		t.Fatalf("Stream: %v", err)
+	}
+	if len(got.counts) != 0 {
+		t.Errorf("counts = %v, want empty: a header must start with \">THREE\", not merely contain it", got.counts)
+	}
+}
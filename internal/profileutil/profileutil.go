@@ -0,0 +1,75 @@
+// Package profileutil provides shared CPU/memory/block profiling setup
+// for the k-nucleotide benchmark variants, so each one can be compared
+// phase-by-phase in go tool pprof instead of eyeballing separate files.
+package profileutil
+
+import (
+	"context"
+	"flag"
+	"os"
+	"runtime"
+	"runtime/pprof"
+)
+
+var (
+	cpuprofile   = flag.String("cpuprofile", "", "write cpu profile to file")
+	memprofile   = flag.String("memprofile", "", "write memory profile to file")
+	blockprofile = flag.String("blockprofile", "", "write block profile to file")
+)
+
+// Start parses the profiling flags, if not already parsed, and begins
+// CPU and block profiling as requested. The returned func must be
+// called, typically via defer, to stop profiling and write the memory
+// and block profiles.
+func Start() func() {
+	if !flag.Parsed() {
+		flag.Parse()
+	}
+
+	if *blockprofile != "" {
+		runtime.SetBlockProfileRate(1)
+	}
+
+	var cpu *os.File
+	if *cpuprofile != "" {
+		f, err := os.Create(*cpuprofile)
+		if err != nil {
+			panic(err)
+		}
+		cpu = f
+		pprof.StartCPUProfile(cpu)
+	}
+
+	return func() {
+		if cpu != nil {
+			pprof.StopCPUProfile()
+			cpu.Close()
+		}
+
+		if *memprofile != "" {
+			f, err := os.Create(*memprofile)
+			if err != nil {
+				panic(err)
+			}
+			runtime.GC()
+			pprof.WriteHeapProfile(f)
+			f.Close()
+		}
+
+		if *blockprofile != "" {
+			f, err := os.Create(*blockprofile)
+			if err != nil {
+				panic(err)
+			}
+			pprof.Lookup("block").WriteTo(f, 0)
+			f.Close()
+		}
+	}
+}
+
+// Do runs fn under a pprof label naming the given pipeline phase, so
+// samples collected between Start and the returned stop func can be
+// filtered by phase in go tool pprof.
+func Do(ctx context.Context, phase string, fn func(context.Context)) {
+	pprof.Do(ctx, pprof.Labels("phase", phase), fn)
+}